@@ -0,0 +1,20 @@
+//go:build linux || darwin
+
+package serial
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// ioctl invokes the ioctl(2) system call against fd with the given request
+// number and pointer argument, returning any resulting error. request is a
+// uint rather than an int because some request numbers (e.g. termios2's
+// TCGETS2 and Darwin's IOSSIOSPEED) exceed math.MaxInt32 and would overflow
+// a 32-bit int.
+func ioctl(request uint, fd uintptr, argp uintptr) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, fd, uintptr(request), argp)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package serial
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// listPorts scans /dev for callout and dial-in tty nodes, then shells out to
+// ioreg to pull whatever USB descriptor metadata is available for each one.
+func listPorts() ([]PortInfo, error) {
+	devices, err := filepath.Glob("/dev/tty.*")
+	if err != nil {
+		return nil, err
+	}
+	calloutDevices, err := filepath.Glob("/dev/cu.*")
+	if err != nil {
+		return nil, err
+	}
+	devices = append(devices, calloutDevices...)
+
+	metadata, err := ioregUSBMetadata()
+	if err != nil {
+		// ioreg may be unavailable in some environments; fall back to bare
+		// device names rather than failing the whole call.
+		metadata = nil
+	}
+
+	ports := make([]PortInfo, 0, len(devices))
+	for _, name := range devices {
+		info := PortInfo{Name: name}
+		if m, ok := metadata[filepath.Base(name)]; ok {
+			info = m
+			info.Name = name
+		}
+		ports = append(ports, info)
+	}
+	return ports, nil
+}
+
+// ioregUSBMetadata runs `ioreg -r -c IOSerialBSDClient -l` and parses out, for
+// each IOCalloutDevice entry, the owning USB device's vendor/product/serial/
+// manufacturer strings, keyed by the bare device name (e.g. "cu.usbserial-1").
+func ioregUSBMetadata() (map[string]PortInfo, error) {
+	out, err := exec.Command("ioreg", "-r", "-c", "IOSerialBSDClient", "-l").Output()
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]PortInfo{}
+	var current PortInfo
+	var currentName string
+
+	flush := func() {
+		if currentName != "" {
+			result[currentName] = current
+		}
+		current = PortInfo{}
+		currentName = ""
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.Contains(line, "IOCalloutDevice"):
+			flush()
+			currentName = filepath.Base(ioregValue(line))
+		case strings.Contains(line, "USB Vendor Name"):
+			current.Manufacturer = ioregValue(line)
+		case strings.Contains(line, "kUSBProductString") || strings.Contains(line, "USB Product Name"):
+			current.Description = ioregValue(line)
+		case strings.Contains(line, "idVendor"):
+			current.VID = decimalToHex4(ioregValue(line))
+		case strings.Contains(line, "idProduct"):
+			current.PID = decimalToHex4(ioregValue(line))
+		case strings.Contains(line, "USB Serial Number"):
+			current.SerialNumber = ioregValue(line)
+		}
+	}
+	flush()
+
+	return result, scanner.Err()
+}
+
+// ioregValue extracts the quoted or bare value on the right-hand side of an
+// ioreg "key" = value line.
+func ioregValue(line string) string {
+	i := strings.Index(line, "=")
+	if i < 0 {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(line[i+1:]), `"`)
+}
+
+// decimalToHex4 reformats a decimal USB vendor/product ID, as reported by
+// ioreg, into the four hex digit string documented on PortInfo.VID/PID
+// (e.g. "0403"). If v isn't a valid decimal number, it's returned unchanged
+// rather than dropped.
+func decimalToHex4(v string) string {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return v
+	}
+	return fmt.Sprintf("%04x", n)
+}
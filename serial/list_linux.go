@@ -0,0 +1,75 @@
+package serial
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// listPorts walks /sys/class/tty, and for every tty backed by a real device,
+// resolves the device symlink to find the owning USB device (if any) so we
+// can report its vendor/product/serial/manufacturer strings.
+func listPorts() ([]PortInfo, error) {
+	ttyDir := "/sys/class/tty"
+	entries, err := os.ReadDir(ttyDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ports []PortInfo
+	for _, entry := range entries {
+		devicePath := filepath.Join(ttyDir, entry.Name(), "device")
+		if _, err := os.Lstat(devicePath); err != nil {
+			continue
+		}
+
+		devNode := filepath.Join("/dev", entry.Name())
+		if _, err := os.Stat(devNode); err != nil {
+			continue
+		}
+
+		resolved, err := filepath.EvalSymlinks(devicePath)
+		if err != nil {
+			continue
+		}
+
+		info := PortInfo{Name: devNode}
+		if usbDir := findUSBDeviceDir(resolved); usbDir != "" {
+			info.VID = readSysAttr(usbDir, "idVendor")
+			info.PID = readSysAttr(usbDir, "idProduct")
+			info.SerialNumber = readSysAttr(usbDir, "serial")
+			info.Manufacturer = readSysAttr(usbDir, "manufacturer")
+			info.Description = readSysAttr(usbDir, "product")
+		}
+
+		ports = append(ports, info)
+	}
+
+	return ports, nil
+}
+
+// findUSBDeviceDir walks up from a resolved /sys/class/tty/*/device path
+// looking for the ancestor directory that describes the owning USB device,
+// i.e. the first one with both idVendor and idProduct attributes.
+func findUSBDeviceDir(dir string) string {
+	for dir != "/" && dir != "." {
+		if hasSysAttr(dir, "idVendor") && hasSysAttr(dir, "idProduct") {
+			return dir
+		}
+		dir = filepath.Dir(dir)
+	}
+	return ""
+}
+
+func hasSysAttr(dir, name string) bool {
+	_, err := os.Stat(filepath.Join(dir, name))
+	return err == nil
+}
+
+func readSysAttr(dir, name string) string {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
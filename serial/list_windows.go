@@ -0,0 +1,141 @@
+package serial
+
+import (
+	"regexp"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	setupapi                              = syscall.NewLazyDLL("setupapi.dll")
+	procSetupDiGetClassDevsW              = setupapi.NewProc("SetupDiGetClassDevsW")
+	procSetupDiEnumDeviceInfo             = setupapi.NewProc("SetupDiEnumDeviceInfo")
+	procSetupDiGetDeviceRegistryPropertyW = setupapi.NewProc("SetupDiGetDeviceRegistryPropertyW")
+	procSetupDiGetDeviceInstanceIdW       = setupapi.NewProc("SetupDiGetDeviceInstanceIdW")
+	procSetupDiDestroyDeviceInfoList      = setupapi.NewProc("SetupDiDestroyDeviceInfoList")
+)
+
+const (
+	digcfPresent         = 0x02
+	digcfDeviceInterface = 0x10
+
+	spdrpMfg          = 0x0B
+	spdrpFriendlyName = 0x0C
+
+	invalidHandleValue = ^uintptr(0)
+)
+
+// guid mirrors the Win32 GUID struct.
+type guid struct {
+	Data1 uint32
+	Data2 uint16
+	Data3 uint16
+	Data4 [8]byte
+}
+
+// guidDevInterfaceComport is GUID_DEVINTERFACE_COMPORT, the device interface
+// class for serial ports.
+var guidDevInterfaceComport = guid{
+	0x86e0d1e0, 0x8089, 0x11d0,
+	[8]byte{0x9c, 0xe4, 0x08, 0x00, 0x3e, 0x30, 0x1f, 0x73},
+}
+
+type spDevInfoData struct {
+	cbSize    uint32
+	ClassGUID guid
+	DevInst   uint32
+	Reserved  uintptr
+}
+
+var comNameRegexp = regexp.MustCompile(`\(COM\d+\)`)
+
+// listPorts enumerates the GUID_DEVINTERFACE_COMPORT device class via
+// SetupDiGetClassDevs, pulling the COM name, friendly name, manufacturer, and
+// VID/PID (parsed out of the device instance ID) for each device found.
+func listPorts() ([]PortInfo, error) {
+	h, _, err := procSetupDiGetClassDevsW.Call(
+		uintptr(unsafe.Pointer(&guidDevInterfaceComport)),
+		0,
+		0,
+		digcfPresent|digcfDeviceInterface)
+	if h == invalidHandleValue {
+		return nil, err
+	}
+	defer procSetupDiDestroyDeviceInfoList.Call(h)
+
+	var ports []PortInfo
+	for i := uint32(0); ; i++ {
+		data := spDevInfoData{}
+		data.cbSize = uint32(unsafe.Sizeof(data))
+
+		r, _, _ := procSetupDiEnumDeviceInfo.Call(h, uintptr(i), uintptr(unsafe.Pointer(&data)))
+		if r == 0 {
+			// ERROR_NO_MORE_ITEMS: we've walked the whole device set.
+			break
+		}
+
+		friendlyName := getDeviceRegistryProperty(h, &data, spdrpFriendlyName)
+		manufacturer := getDeviceRegistryProperty(h, &data, spdrpMfg)
+		instanceID := getDeviceInstanceID(h, &data)
+
+		name := comNameRegexp.FindString(friendlyName)
+		name = strings.Trim(name, "()")
+		if name == "" {
+			continue
+		}
+
+		info := PortInfo{
+			Name:         name,
+			Description:  friendlyName,
+			Manufacturer: manufacturer,
+		}
+		info.VID, info.PID = parseVIDPID(instanceID)
+		ports = append(ports, info)
+	}
+
+	return ports, nil
+}
+
+func getDeviceRegistryProperty(h uintptr, data *spDevInfoData, property uint32) string {
+	var buf [512]uint16
+	r, _, _ := procSetupDiGetDeviceRegistryPropertyW.Call(
+		h,
+		uintptr(unsafe.Pointer(data)),
+		uintptr(property),
+		0,
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(len(buf)*2),
+		0)
+	if r == 0 {
+		return ""
+	}
+	return syscall.UTF16ToString(buf[:])
+}
+
+func getDeviceInstanceID(h uintptr, data *spDevInfoData) string {
+	var buf [512]uint16
+	r, _, _ := procSetupDiGetDeviceInstanceIdW.Call(
+		h,
+		uintptr(unsafe.Pointer(data)),
+		uintptr(unsafe.Pointer(&buf[0])),
+		uintptr(len(buf)),
+		0)
+	if r == 0 {
+		return ""
+	}
+	return syscall.UTF16ToString(buf[:])
+}
+
+// parseVIDPID pulls the vendor/product IDs out of a USB device instance ID
+// like "USB\VID_0403&PID_6001\A9010001".
+func parseVIDPID(instanceID string) (vid, pid string) {
+	upper := strings.ToUpper(instanceID)
+	if i := strings.Index(upper, "VID_"); i >= 0 && i+8 <= len(upper) {
+		vid = upper[i+4 : i+8]
+	}
+	if i := strings.Index(upper, "PID_"); i >= 0 && i+8 <= len(upper) {
+		pid = upper[i+4 : i+8]
+	}
+	return vid, pid
+}
@@ -1,7 +1,6 @@
 package serial
 
 import (
-	"fmt"
 	"os"
 	"syscall"
 	"unsafe"
@@ -14,6 +13,10 @@ const (
 	IOSSIOSPEED = 0x80045402
 )
 
+// cmspar would select CMSPAR (stick/Mark-Space parity), but Darwin's
+// termios API has no equivalent bit, so it is always zero here.
+const cmspar = 0
+
 // makeTermios returns a pointer to an instantiates termios2 struct, based on the given
 // OpenOptions. Termios is a Linux extension which allows arbitrary baud rates
 // to be specified.
@@ -23,7 +26,6 @@ func makeTermios(fd uintptr, options OpenOptions) (*unix.Termios, error) {
 
 	err := unix.IoctlSetTermios(int(fd), unix.TIOCGETA, t)
 	if err != nil {
-		fmt.Println("TCGETS openInternal err")
 		return nil, err
 	}
 
@@ -41,16 +43,58 @@ func makeTermios(fd uintptr, options OpenOptions) (*unix.Termios, error) {
 
 	// character size
 	t.Cflag &= ^uint64(syscall.CSIZE)
-	t.Cflag |= uint64(syscall.CS8)
+	switch options.DataBits {
+	case 5:
+		t.Cflag |= uint64(syscall.CS5)
+	case 6:
+		t.Cflag |= uint64(syscall.CS6)
+	case 7:
+		t.Cflag |= uint64(syscall.CS7)
+	default:
+		t.Cflag |= uint64(syscall.CS8)
+	}
 
 	// setup stop bits
-	t.Cflag &= ^uint64(syscall.CSTOPB)
+	// Darwin's termios has no separate notion of 1.5 stop bits, so treat it
+	// the same as 2.
+	if options.StopBits == Stop2 || options.StopBits == Stop1Half {
+		t.Cflag |= uint64(syscall.CSTOPB)
+	} else {
+		t.Cflag &= ^uint64(syscall.CSTOPB)
+	}
 
 	// setup parity
 	t.Iflag &= ^uint64(syscall.INPCK | syscall.ISTRIP)
-	t.Cflag &= ^uint64(syscall.PARENB | syscall.PARODD)
+	t.Cflag &= ^uint64(syscall.PARENB | syscall.PARODD | cmspar)
+	switch options.Parity {
+	case ParityOdd:
+		t.Cflag |= uint64(syscall.PARENB | syscall.PARODD)
+		t.Iflag |= uint64(syscall.INPCK | syscall.ISTRIP)
+	case ParityEven:
+		t.Cflag |= uint64(syscall.PARENB)
+		t.Iflag |= uint64(syscall.INPCK | syscall.ISTRIP)
+	case ParityMark:
+		// Mark/Space parity relies on CMSPAR, which the Darwin termios API
+		// does not expose. Fall back to plain odd parity.
+		t.Cflag |= uint64(syscall.PARENB | syscall.PARODD)
+		t.Iflag |= uint64(syscall.INPCK | syscall.ISTRIP)
+	case ParitySpace:
+		t.Cflag |= uint64(syscall.PARENB)
+		t.Iflag |= uint64(syscall.INPCK | syscall.ISTRIP)
+	}
 
-	t.Iflag &= ^uint64(syscall.IXON | syscall.IXOFF | syscall.IXANY)
+	// setup flow control
+	if options.RTSCTSFlowControl {
+		t.Cflag |= uint64(unix.CRTSCTS)
+	} else {
+		t.Cflag &= ^uint64(unix.CRTSCTS)
+	}
+
+	if options.XONXOFFFlowControl {
+		t.Iflag |= uint64(syscall.IXON | syscall.IXOFF | syscall.IXANY)
+	} else {
+		t.Iflag &= ^uint64(syscall.IXON | syscall.IXOFF | syscall.IXANY)
+	}
 
 	// // Sanity check inter-character timeout and minimum read size options.
 	// // See serial.go for more information on vtime/vmin -- these only work in non-canonical mode
@@ -103,5 +147,5 @@ func openInternal(options OpenOptions) (*Port, error) {
 		return nil, errcode
 	}
 
-	return NewPort(file, fd, options), nil
+	return NewPort(file, fd, options)
 }
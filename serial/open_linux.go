@@ -0,0 +1,168 @@
+package serial
+
+import (
+	"os"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// tcgets2/tcsets2 get/set a termios2 struct. golang.org/x/sys/unix does
+	// not expose these (or the termios2 type), so we drive them with raw
+	// ioctl(2) calls, the same way rs485_linux.go drives TIOCSRS485.
+	tcgets2 = 0x802C542A
+	tcsets2 = 0x402C542B
+
+	ncc2 = 19
+)
+
+// termios2 mirrors the kernel's struct termios2, which extends plain
+// termios with c_ispeed/c_ospeed so BOTHER can request arbitrary baud rates.
+type termios2 struct {
+	Iflag  uint32
+	Oflag  uint32
+	Cflag  uint32
+	Lflag  uint32
+	Line   uint8
+	Cc     [ncc2]uint8
+	Ispeed uint32
+	Ospeed uint32
+}
+
+// makeTermios returns a pointer to an instantiated termios2 struct, based on
+// the given OpenOptions. Termios2/BOTHER is a Linux extension which allows
+// arbitrary baud rates to be specified, not just the fixed Bxxxx constants.
+func makeTermios(fd uintptr, options OpenOptions) (*termios2, error) {
+	t := &termios2{}
+	if err := ioctl(tcgets2, fd, uintptr(unsafe.Pointer(t))); err != nil {
+		return nil, err
+	}
+
+	t.Cflag |= unix.CLOCAL | unix.CREAD
+	t.Lflag &^= unix.ICANON | unix.ECHO | unix.ECHOE |
+		unix.ECHOK | unix.ECHONL | unix.ISIG | unix.IEXTEN
+	t.Lflag &^= unix.ECHOCTL
+	t.Lflag &^= unix.ECHOKE
+
+	t.Oflag &^= unix.OPOST | unix.ONLCR | unix.OCRNL
+	t.Iflag &^= unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IGNBRK
+	t.Iflag &^= unix.PARMRK
+
+	// character size
+	t.Cflag &^= unix.CSIZE
+	switch options.DataBits {
+	case 5:
+		t.Cflag |= unix.CS5
+	case 6:
+		t.Cflag |= unix.CS6
+	case 7:
+		t.Cflag |= unix.CS7
+	default:
+		t.Cflag |= unix.CS8
+	}
+
+	// setup stop bits
+	if options.StopBits == Stop2 || options.StopBits == Stop1Half {
+		t.Cflag |= unix.CSTOPB
+	} else {
+		t.Cflag &^= unix.CSTOPB
+	}
+
+	// setup parity
+	t.Iflag &^= unix.INPCK | unix.ISTRIP
+	t.Cflag &^= unix.PARENB | unix.PARODD | unix.CMSPAR
+	switch options.Parity {
+	case ParityOdd:
+		t.Cflag |= unix.PARENB | unix.PARODD
+		t.Iflag |= unix.INPCK | unix.ISTRIP
+	case ParityEven:
+		t.Cflag |= unix.PARENB
+		t.Iflag |= unix.INPCK | unix.ISTRIP
+	case ParityMark:
+		t.Cflag |= unix.PARENB | unix.PARODD | unix.CMSPAR
+		t.Iflag |= unix.INPCK | unix.ISTRIP
+	case ParitySpace:
+		t.Cflag |= unix.PARENB | unix.CMSPAR
+		t.Iflag |= unix.INPCK | unix.ISTRIP
+	}
+
+	// setup flow control
+	if options.RTSCTSFlowControl {
+		t.Cflag |= unix.CRTSCTS
+	} else {
+		t.Cflag &^= unix.CRTSCTS
+	}
+
+	if options.XONXOFFFlowControl {
+		t.Iflag |= unix.IXON | unix.IXOFF | unix.IXANY
+	} else {
+		t.Iflag &^= unix.IXON | unix.IXOFF | unix.IXANY
+	}
+
+	// Arbitrary baud rate via the termios2 BOTHER extension, so we are not
+	// limited to the fixed set of Bxxxx constants.
+	t.Cflag &^= unix.CBAUD
+	t.Cflag |= unix.BOTHER
+	t.Ispeed = uint32(options.BaudRate)
+	t.Ospeed = uint32(options.BaudRate)
+
+	// Sanity check inter-character timeout and minimum read size options.
+	// See serial.go for more information on vtime/vmin -- these only work in non-canonical mode
+	vtime := uint(round(float64(options.InterCharacterTimeout)/100.0) * 100)
+	vmin := options.MinimumReadSize
+
+	t.Cc[unix.VTIME] = uint8(vtime / 100)
+	t.Cc[unix.VMIN] = uint8(vmin)
+
+	return t, nil
+}
+
+// openInternal is the operating system specific port opening, given the OpenOptions
+func openInternal(options OpenOptions) (*Port, error) {
+	// Open the file with RDWR, NOCTTY, NONBLOCK flags
+	// RDWR     : read/write
+	// NOCTTY   : don't allow the port to become the controlling terminal
+	// NONBLOCK : open with nonblocking so we don't stall
+	file, openErr :=
+		os.OpenFile(
+			options.PortName,
+			unix.O_RDWR|unix.O_NOCTTY|unix.O_NONBLOCK,
+			0777)
+	if openErr != nil {
+		return nil, openErr
+	}
+
+	fd := file.Fd()
+
+	// When we call Fd(), we make the file descriptor blocking, which we don't want
+	// Let's unset the blocking flag and save the pointer for later.
+	nonblockErr := unix.SetNonblock(int(fd), true)
+	if nonblockErr != nil {
+		return nil, nonblockErr
+	}
+
+	t, optErr := makeTermios(fd, options)
+	if optErr != nil {
+		return nil, optErr
+	}
+
+	// Set our termios2 struct as the file descriptor's settings
+	err := ioctl(tcsets2, fd, uintptr(unsafe.Pointer(t)))
+	if err != nil {
+		return nil, err
+	}
+
+	port, portErr := NewPort(file, fd, options)
+	if portErr != nil {
+		return nil, portErr
+	}
+
+	if options.RS485 != (RS485Config{}) {
+		if err := port.SetRS485(options.RS485); err != nil {
+			return nil, err
+		}
+	}
+
+	return port, nil
+}
@@ -0,0 +1,188 @@
+package serial
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32            = syscall.NewLazyDLL("kernel32.dll")
+	procCreateFileW     = kernel32.NewProc("CreateFileW")
+	procGetCommState    = kernel32.NewProc("GetCommState")
+	procSetCommState    = kernel32.NewProc("SetCommState")
+	procSetCommTimeouts = kernel32.NewProc("SetCommTimeouts")
+)
+
+// dcb mirrors the Win32 DCB struct. The bitfield flags (fBinary, fParity,
+// fOutxCtsFlow, fRtsControl, ...) are packed into a single DWORD, as they are
+// in the C struct.
+type dcb struct {
+	DCBlength  uint32
+	BaudRate   uint32
+	Flags      uint32
+	wReserved  uint16
+	XonLim     uint16
+	XoffLim    uint16
+	ByteSize   byte
+	Parity     byte
+	StopBits   byte
+	XonChar    byte
+	XoffChar   byte
+	ErrorChar  byte
+	EofChar    byte
+	EvtChar    byte
+	wReserved1 uint16
+}
+
+const (
+	dcbFlagBinary      = 1 << 0
+	dcbFlagParity      = 1 << 1
+	dcbFlagOutxCtsFlow = 1 << 2
+	dcbFlagOutX        = 1 << 8
+	dcbFlagInX         = 1 << 9
+	// fRtsControl is the two-bit field at bits 12-13; RTS_CONTROL_HANDSHAKE
+	// is 2.
+	dcbFlagRtsControlMask      = 3 << 12
+	dcbFlagRtsControlHandshake = 2 << 12
+)
+
+const (
+	noParity    = 0
+	oddParity   = 1
+	evenParity  = 2
+	markParity  = 3
+	spaceParity = 4
+)
+
+const (
+	oneStopBit   = 0
+	one5StopBits = 1
+	twoStopBits  = 2
+)
+
+const (
+	genericRead  = 0x80000000
+	genericWrite = 0x40000000
+	openExisting = 3
+)
+
+// commTimeouts mirrors the Win32 COMMTIMEOUTS struct.
+type commTimeouts struct {
+	ReadIntervalTimeout         uint32
+	ReadTotalTimeoutMultiplier  uint32
+	ReadTotalTimeoutConstant    uint32
+	WriteTotalTimeoutMultiplier uint32
+	WriteTotalTimeoutConstant   uint32
+}
+
+// makeDCB returns a DCB configured according to the given OpenOptions, ready
+// to be installed on a comm handle with SetCommState.
+func makeDCB(h syscall.Handle, options OpenOptions) (dcb, error) {
+	var d dcb
+	d.DCBlength = uint32(unsafe.Sizeof(d))
+
+	r, _, err := procGetCommState.Call(uintptr(h), uintptr(unsafe.Pointer(&d)))
+	if r == 0 {
+		return d, err
+	}
+
+	d.BaudRate = uint32(options.BaudRate)
+
+	switch options.DataBits {
+	case 5, 6, 7:
+		d.ByteSize = byte(options.DataBits)
+	default:
+		d.ByteSize = 8
+	}
+
+	switch options.StopBits {
+	case Stop1Half:
+		d.StopBits = one5StopBits
+	case Stop2:
+		d.StopBits = twoStopBits
+	default:
+		d.StopBits = oneStopBit
+	}
+
+	d.Flags &^= dcbFlagParity
+	switch options.Parity {
+	case ParityOdd:
+		d.Parity = oddParity
+		d.Flags |= dcbFlagParity
+	case ParityEven:
+		d.Parity = evenParity
+		d.Flags |= dcbFlagParity
+	case ParityMark:
+		d.Parity = markParity
+		d.Flags |= dcbFlagParity
+	case ParitySpace:
+		d.Parity = spaceParity
+		d.Flags |= dcbFlagParity
+	default:
+		d.Parity = noParity
+	}
+
+	d.Flags |= dcbFlagBinary
+
+	if options.RTSCTSFlowControl {
+		d.Flags &^= dcbFlagRtsControlMask
+		d.Flags |= dcbFlagOutxCtsFlow | dcbFlagRtsControlHandshake
+	} else {
+		d.Flags &^= dcbFlagOutxCtsFlow | dcbFlagRtsControlMask
+	}
+
+	if options.XONXOFFFlowControl {
+		d.Flags |= dcbFlagOutX | dcbFlagInX
+		d.XonChar = 0x11
+		d.XoffChar = 0x13
+	} else {
+		d.Flags &^= dcbFlagOutX | dcbFlagInX
+	}
+
+	return d, nil
+}
+
+// openInternal is the operating system specific port opening, given the OpenOptions
+func openInternal(options OpenOptions) (*Port, error) {
+	path, err := syscall.UTF16PtrFromString(`\\.\` + options.PortName)
+	if err != nil {
+		return nil, err
+	}
+
+	// No FILE_FLAG_OVERLAPPED: Read/Write below call ReadFile/WriteFile with a
+	// nil *syscall.Overlapped, which requires a synchronous handle.
+	r, _, callErr := procCreateFileW.Call(
+		uintptr(unsafe.Pointer(path)),
+		genericRead|genericWrite,
+		0,
+		0,
+		openExisting,
+		0,
+		0)
+	h := syscall.Handle(r)
+	if h == syscall.InvalidHandle {
+		return nil, callErr
+	}
+
+	d, err := makeDCB(h, options)
+	if err != nil {
+		syscall.CloseHandle(h)
+		return nil, err
+	}
+
+	if r, _, callErr := procSetCommState.Call(uintptr(h), uintptr(unsafe.Pointer(&d))); r == 0 {
+		syscall.CloseHandle(h)
+		return nil, fmt.Errorf("SetCommState: %w", callErr)
+	}
+
+	timeouts := commTimeouts{
+		ReadIntervalTimeout: uint32(options.InterCharacterTimeout),
+	}
+	if r, _, callErr := procSetCommTimeouts.Call(uintptr(h), uintptr(unsafe.Pointer(&timeouts))); r == 0 {
+		syscall.CloseHandle(h)
+		return nil, fmt.Errorf("SetCommTimeouts: %w", callErr)
+	}
+
+	return NewPort(h, options), nil
+}
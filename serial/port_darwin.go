@@ -2,6 +2,7 @@ package serial
 
 import (
 	"os"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -13,12 +14,44 @@ type Port struct {
 	f          *os.File
 	fd         uintptr
 	DeviceName string
+
+	// closeR/closeW are the two ends of a pipe used to unblock a Read that
+	// is parked in waitReadable when Close is called.
+	closeR, closeW int
+	closeOnce      sync.Once
+
+	// mu is held for reading for the duration of a Read call, and for
+	// writing by Close, so Close can't release the fd out from under an
+	// in-flight Read.
+	mu sync.RWMutex
+
+	readTimeout atomicDuration
+}
+
+// SetReadTimeout sets the maximum time Read will block waiting for data
+// before returning os.ErrDeadlineExceeded. A timeout of zero (the default)
+// blocks indefinitely.
+func (p *Port) SetReadTimeout(timeout time.Duration) {
+	p.readTimeout.Store(timeout)
 }
 
-// Read reads up to len(b) bytes from the Port's file.
-// It will return the number of bytes read and an error, if any
+// Read reads up to len(b) bytes from the Port's file, blocking until data is
+// available, the read timeout set via SetReadTimeout elapses, or the Port is
+// Closed.
 func (p *Port) Read(b []byte) (int, error) {
-	return p.f.Read(b)
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for {
+		if err := waitReadable(p.fd, p.closeR, p.readTimeout.Load()); err != nil {
+			return 0, err
+		}
+		n, err := p.f.Read(b)
+		if err == unix.EAGAIN || err == unix.EWOULDBLOCK {
+			continue
+		}
+		return n, err
+	}
 }
 
 // Write writes len(b) number of bytes to the Port's file.
@@ -27,13 +60,28 @@ func (p *Port) Write(b []byte) (int, error) {
 	return p.f.Write(b)
 }
 
-// Close closes the Port's file, making it unusable for I/O
+// Close closes the Port's file, making it unusable for I/O. Any Read blocked
+// in waitReadable is woken with ErrPortClosed. Close is idempotent: the
+// whole close path, including the fd teardown, runs at most once, so a
+// second call never re-closes (and potentially corrupts) fd numbers the OS
+// may have already recycled.
 func (p *Port) Close() error {
-	return p.f.Close()
+	var err error
+	p.closeOnce.Do(func() {
+		unix.Write(p.closeW, []byte{0})
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		err = p.f.Close()
+		unix.Close(p.closeR)
+		unix.Close(p.closeW)
+	})
+	return err
 }
 
 // var FIONREAD = 0x541B
-var TIOCINQ = 0x4004667f
+var TIOCINQ uint = 0x4004667f
 
 // InWaiting returns the number of waiting bytes in the Port's internal buffer.
 func (p *Port) InWaiting() (int, error) {
@@ -46,14 +94,14 @@ func (p *Port) InWaiting() (int, error) {
 	return waiting, nil
 }
 
-var TCFLSH = 0x540b
+var TCFLSH uint = 0x540b
 
 func (p *Port) ResetInputBuffer() error {
-	return ioctl(TCFLSH, p.fd, unix.TCIFLUSH)
+	return ioctl(TCFLSH, p.fd, uintptr(unix.TCIFLUSH))
 }
 
 func (p *Port) ResetOutputBuffer() error {
-	return ioctl(TCFLSH, p.fd, unix.TCOFLUSH)
+	return ioctl(TCFLSH, p.fd, uintptr(unix.TCOFLUSH))
 }
 
 // SetDeadline sets the read and write deadlines for the Port's file.
@@ -98,7 +146,7 @@ func (p *Port) RTS() (bool, error) {
 // SetDTR sets the status of the DTR line of a port to the given state,
 // allowing manual control of the Data Terminal Ready modem line.
 func (p *Port) SetDTR(state bool) error {
-	var command int
+	var command uint
 	dtrFlag := unix.TIOCM_DTR
 	if state {
 		command = unix.TIOCMBIS
@@ -114,7 +162,7 @@ func (p *Port) SetDTR(state bool) error {
 
 // SetRTS sets the status of the RTS line of a port to the given state,
 func (p *Port) SetRTS(state bool) error {
-	var command int
+	var command uint
 	flag := unix.TIOCM_RTS
 	if state {
 		command = unix.TIOCMBIS
@@ -128,7 +176,67 @@ func (p *Port) SetRTS(state bool) error {
 	return nil
 }
 
+// CTS returns the status of the Clear To Send (CTS) modem status line.
+func (p *Port) CTS() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	return status&unix.TIOCM_CTS > 0, nil
+}
+
+// DSR returns the status of the Data Set Ready (DSR) modem status line.
+func (p *Port) DSR() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	return status&unix.TIOCM_DSR > 0, nil
+}
+
+// RI returns the status of the Ring Indicator (RI) modem status line.
+func (p *Port) RI() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	return status&unix.TIOCM_RI > 0, nil
+}
+
+// DCD returns the status of the Data Carrier Detect (DCD) modem status line.
+func (p *Port) DCD() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	return status&unix.TIOCM_CD > 0, nil
+}
+
+// ModemStatus reads all six modem control lines in a single call.
+func (p *Port) ModemStatus() (ModemStatus, error) {
+	var status int
+	if err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status))); err != nil {
+		return ModemStatus{}, err
+	}
+	return ModemStatus{
+		DTR: status&unix.TIOCM_DTR > 0,
+		RTS: status&unix.TIOCM_RTS > 0,
+		CTS: status&unix.TIOCM_CTS > 0,
+		DSR: status&unix.TIOCM_DSR > 0,
+		RI:  status&unix.TIOCM_RI > 0,
+		DCD: status&unix.TIOCM_CD > 0,
+	}, nil
+}
+
 // NewPort creates and returns a new Port struct using the given os.File pointer
-func NewPort(f *os.File, fd uintptr, options OpenOptions) *Port {
-	return &Port{f, fd, options.PortName}
+func NewPort(f *os.File, fd uintptr, options OpenOptions) (*Port, error) {
+	closeR, closeW, err := newCloseSignal()
+	if err != nil {
+		return nil, err
+	}
+	return &Port{f: f, fd: fd, DeviceName: options.PortName, closeR: closeR, closeW: closeW}, nil
 }
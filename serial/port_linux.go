@@ -0,0 +1,272 @@
+package serial
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// tiocmiwait blocks until one of the requested modem status lines changes
+// state. It is a Linux-only extension; there is no portable equivalent.
+const tiocmiwait = 0x545C
+
+// fionread is not exposed by golang.org/x/sys/unix on Linux, so it's
+// hardcoded here the same way the original Darwin file hardcoded its own
+// (different) TIOCINQ value.
+const fionread = 0x541B
+
+// Port represents a File opened with serial port options
+type Port struct {
+	f          *os.File
+	fd         uintptr
+	DeviceName string
+
+	// closeR/closeW are the two ends of a pipe used to unblock a Read that
+	// is parked in waitReadable when Close is called.
+	closeR, closeW int
+	closeOnce      sync.Once
+
+	// mu is held for reading for the duration of a Read call, and for
+	// writing by Close, so Close can't release the fd out from under an
+	// in-flight Read.
+	mu sync.RWMutex
+
+	readTimeout atomicDuration
+}
+
+// SetReadTimeout sets the maximum time Read will block waiting for data
+// before returning os.ErrDeadlineExceeded. A timeout of zero (the default)
+// blocks indefinitely.
+func (p *Port) SetReadTimeout(timeout time.Duration) {
+	p.readTimeout.Store(timeout)
+}
+
+// Read reads up to len(b) bytes from the Port's file, blocking until data is
+// available, the read timeout set via SetReadTimeout elapses, or the Port is
+// Closed.
+func (p *Port) Read(b []byte) (int, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for {
+		if err := waitReadable(p.fd, p.closeR, p.readTimeout.Load()); err != nil {
+			return 0, err
+		}
+		n, err := p.f.Read(b)
+		if err == unix.EAGAIN || err == unix.EWOULDBLOCK {
+			continue
+		}
+		return n, err
+	}
+}
+
+// Write writes len(b) number of bytes to the Port's file.
+// It will return the number of bytes written and an error, if any
+func (p *Port) Write(b []byte) (int, error) {
+	return p.f.Write(b)
+}
+
+// Close closes the Port's file, making it unusable for I/O. Any Read blocked
+// in waitReadable is woken with ErrPortClosed. Close is idempotent: the
+// whole close path, including the fd teardown, runs at most once, so a
+// second call never re-closes (and potentially corrupts) fd numbers the OS
+// may have already recycled.
+func (p *Port) Close() error {
+	var err error
+	p.closeOnce.Do(func() {
+		unix.Write(p.closeW, []byte{0})
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		err = p.f.Close()
+		unix.Close(p.closeR)
+		unix.Close(p.closeW)
+	})
+	return err
+}
+
+// InWaiting returns the number of waiting bytes in the Port's internal buffer.
+func (p *Port) InWaiting() (int, error) {
+	var waiting int
+	err := ioctl(fionread, p.fd, uintptr(unsafe.Pointer(&waiting)))
+	if err != nil {
+		return 0, err
+	}
+	return waiting, nil
+}
+
+func (p *Port) ResetInputBuffer() error {
+	return ioctl(unix.TCFLSH, p.fd, uintptr(unix.TCIFLUSH))
+}
+
+func (p *Port) ResetOutputBuffer() error {
+	return ioctl(unix.TCFLSH, p.fd, uintptr(unix.TCOFLUSH))
+}
+
+// SetDeadline sets the read and write deadlines on the Port's underlying
+// file. Read waits for data in select(2), which does not observe this
+// deadline; use SetReadTimeout to bound how long Read waits.
+func (p *Port) SetDeadline(t time.Time) error {
+	return p.f.SetDeadline(t)
+}
+
+// DTR returns the status of the Data Terminal Ready (DTR) line of the port.
+// See: https://en.wikipedia.org/wiki/Data_Terminal_Ready
+func (p *Port) DTR() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	if status&unix.TIOCM_DTR > 0 {
+		return true, nil
+	}
+	return false, nil
+}
+
+// RTS
+// See: https://en.wikipedia.org/wiki/Data_Terminal_Ready
+func (p *Port) RTS() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	if status&unix.TIOCM_RTS > 0 {
+		return true, nil
+	}
+	return false, nil
+}
+
+// SetDTR sets the status of the DTR line of a port to the given state,
+// allowing manual control of the Data Terminal Ready modem line.
+func (p *Port) SetDTR(state bool) error {
+	var command uint
+	dtrFlag := unix.TIOCM_DTR
+	if state {
+		command = unix.TIOCMBIS
+	} else {
+		command = unix.TIOCMBIC
+	}
+	err := ioctl(command, p.fd, uintptr(unsafe.Pointer(&dtrFlag)))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetRTS sets the status of the RTS line of a port to the given state,
+func (p *Port) SetRTS(state bool) error {
+	var command uint
+	flag := unix.TIOCM_RTS
+	if state {
+		command = unix.TIOCMBIS
+	} else {
+		command = unix.TIOCMBIC
+	}
+	err := ioctl(command, p.fd, uintptr(unsafe.Pointer(&flag)))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CTS returns the status of the Clear To Send (CTS) modem status line.
+func (p *Port) CTS() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	return status&unix.TIOCM_CTS > 0, nil
+}
+
+// DSR returns the status of the Data Set Ready (DSR) modem status line.
+func (p *Port) DSR() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	return status&unix.TIOCM_DSR > 0, nil
+}
+
+// RI returns the status of the Ring Indicator (RI) modem status line.
+func (p *Port) RI() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	return status&unix.TIOCM_RI > 0, nil
+}
+
+// DCD returns the status of the Data Carrier Detect (DCD) modem status line.
+func (p *Port) DCD() (bool, error) {
+	var status int
+	err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status)))
+	if err != nil {
+		return false, err
+	}
+	return status&unix.TIOCM_CD > 0, nil
+}
+
+// ModemStatus reads all six modem control lines in a single call.
+func (p *Port) ModemStatus() (ModemStatus, error) {
+	var status int
+	if err := ioctl(unix.TIOCMGET, p.fd, uintptr(unsafe.Pointer(&status))); err != nil {
+		return ModemStatus{}, err
+	}
+	return ModemStatus{
+		DTR: status&unix.TIOCM_DTR > 0,
+		RTS: status&unix.TIOCM_RTS > 0,
+		CTS: status&unix.TIOCM_CTS > 0,
+		DSR: status&unix.TIOCM_DSR > 0,
+		RI:  status&unix.TIOCM_RI > 0,
+		DCD: status&unix.TIOCM_CD > 0,
+	}, nil
+}
+
+// WaitForModemChange blocks until one of the modem status lines selected by
+// mask (a bitwise OR of unix.TIOCM_CTS, unix.TIOCM_DSR, unix.TIOCM_RI, and
+// unix.TIOCM_CD) changes state, then returns the new ModemStatus. If ctx is
+// cancelled first, ctx.Err() is returned at once and the Port is left open.
+// The wait itself runs on a private dup of the port's fd in a background
+// goroutine, which closes the dup once a selected line changes.
+func (p *Port) WaitForModemChange(ctx context.Context, mask uint) (ModemStatus, error) {
+	dupFd, err := unix.Dup(int(p.fd))
+	if err != nil {
+		return ModemStatus{}, err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		err := ioctl(tiocmiwait, uintptr(dupFd), uintptr(mask))
+		unix.Close(dupFd)
+		done <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ModemStatus{}, ctx.Err()
+	case err := <-done:
+		if err != nil {
+			return ModemStatus{}, err
+		}
+		return p.ModemStatus()
+	}
+}
+
+// NewPort creates and returns a new Port struct using the given os.File pointer
+func NewPort(f *os.File, fd uintptr, options OpenOptions) (*Port, error) {
+	closeR, closeW, err := newCloseSignal()
+	if err != nil {
+		return nil, err
+	}
+	return &Port{f: f, fd: fd, DeviceName: options.PortName, closeR: closeR, closeW: closeW}, nil
+}
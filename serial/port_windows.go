@@ -0,0 +1,229 @@
+package serial
+
+import (
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	procClearCommError     = kernel32.NewProc("ClearCommError")
+	procPurgeComm          = kernel32.NewProc("PurgeComm")
+	procEscapeCommFunction = kernel32.NewProc("EscapeCommFunction")
+	procGetCommModemStatus = kernel32.NewProc("GetCommModemStatus")
+)
+
+const (
+	purgeRxClear = 0x0008
+	purgeTxClear = 0x0004
+)
+
+const (
+	setRTS = 3
+	clrRTS = 4
+	setDTR = 5
+	clrDTR = 6
+)
+
+const (
+	msCTSOn  = 0x0010
+	msDSROn  = 0x0020
+	msRingOn = 0x0040
+	msRLSDOn = 0x0080
+)
+
+// comstat mirrors the Win32 COMSTAT struct. The status bitfield (fCtsHold,
+// fDsrHold, ...) is packed into a single DWORD, as it is in the C struct.
+type comstat struct {
+	Flags  uint32
+	InQue  uint32
+	OutQue uint32
+}
+
+// Port represents a Windows comm handle opened with serial port options.
+type Port struct {
+	h          syscall.Handle
+	DeviceName string
+
+	// dtr and rts track the state of the DTR/RTS output lines, since
+	// Windows offers no API to read back the state we last requested.
+	dtr, rts bool
+}
+
+// Read reads up to len(b) bytes from the Port's handle.
+// It will return the number of bytes read and an error, if any
+func (p *Port) Read(b []byte) (int, error) {
+	var n uint32
+	err := syscall.ReadFile(p.h, b, &n, nil)
+	return int(n), err
+}
+
+// Write writes len(b) number of bytes to the Port's handle.
+// It will return the number of bytes written and an error, if any
+func (p *Port) Write(b []byte) (int, error) {
+	var n uint32
+	err := syscall.WriteFile(p.h, b, &n, nil)
+	return int(n), err
+}
+
+// Close closes the Port's handle, making it unusable for I/O
+func (p *Port) Close() error {
+	return syscall.CloseHandle(p.h)
+}
+
+// InWaiting returns the number of waiting bytes in the Port's internal buffer.
+func (p *Port) InWaiting() (int, error) {
+	var stat comstat
+	r, _, err := procClearCommError.Call(uintptr(p.h), 0, uintptr(unsafe.Pointer(&stat)))
+	if r == 0 {
+		return 0, err
+	}
+	return int(stat.InQue), nil
+}
+
+func (p *Port) ResetInputBuffer() error {
+	r, _, err := procPurgeComm.Call(uintptr(p.h), purgeRxClear)
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+func (p *Port) ResetOutputBuffer() error {
+	r, _, err := procPurgeComm.Call(uintptr(p.h), purgeTxClear)
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+// SetDeadline sets the read timeout for the Port's handle to the time left
+// until t, measured when SetDeadline is called (Windows comm timeouts have no
+// direct equivalent of a deadline). A Read then returns at once with any
+// buffered bytes, or waits up to that long for the first byte to arrive.
+// Writes are not given a timeout.
+func (p *Port) SetDeadline(t time.Time) error {
+	timeouts := commTimeouts{
+		ReadIntervalTimeout:        0xFFFFFFFF,
+		ReadTotalTimeoutMultiplier: 0xFFFFFFFF,
+		ReadTotalTimeoutConstant:   uint32(time.Until(t).Milliseconds()),
+	}
+	r, _, err := procSetCommTimeouts.Call(uintptr(p.h), uintptr(unsafe.Pointer(&timeouts)))
+	if r == 0 {
+		return err
+	}
+	return nil
+}
+
+func modemStatus(h syscall.Handle) (uint32, error) {
+	var status uint32
+	r, _, err := procGetCommModemStatus.Call(uintptr(h), uintptr(unsafe.Pointer(&status)))
+	if r == 0 {
+		return 0, err
+	}
+	return status, nil
+}
+
+// DTR returns the status of the Data Terminal Ready (DTR) line of the port,
+// as last set via SetDTR. Windows offers no API to read this back from the
+// hardware, so we report the state we last requested.
+// See: https://en.wikipedia.org/wiki/Data_Terminal_Ready
+func (p *Port) DTR() (bool, error) {
+	return p.dtr, nil
+}
+
+// RTS reports the status of the RTS line of the port, as last set via
+// SetRTS.
+// See: https://en.wikipedia.org/wiki/Data_Terminal_Ready
+func (p *Port) RTS() (bool, error) {
+	return p.rts, nil
+}
+
+// SetDTR sets the status of the DTR line of a port to the given state,
+// allowing manual control of the Data Terminal Ready modem line.
+func (p *Port) SetDTR(state bool) error {
+	fn := uintptr(clrDTR)
+	if state {
+		fn = setDTR
+	}
+	r, _, err := procEscapeCommFunction.Call(uintptr(p.h), fn)
+	if r == 0 {
+		return err
+	}
+	p.dtr = state
+	return nil
+}
+
+// SetRTS sets the status of the RTS line of a port to the given state,
+func (p *Port) SetRTS(state bool) error {
+	fn := uintptr(clrRTS)
+	if state {
+		fn = setRTS
+	}
+	r, _, err := procEscapeCommFunction.Call(uintptr(p.h), fn)
+	if r == 0 {
+		return err
+	}
+	p.rts = state
+	return nil
+}
+
+// CTS returns the status of the Clear To Send (CTS) modem status line.
+func (p *Port) CTS() (bool, error) {
+	status, err := modemStatus(p.h)
+	if err != nil {
+		return false, err
+	}
+	return status&msCTSOn > 0, nil
+}
+
+// DSR returns the status of the Data Set Ready (DSR) modem status line.
+func (p *Port) DSR() (bool, error) {
+	status, err := modemStatus(p.h)
+	if err != nil {
+		return false, err
+	}
+	return status&msDSROn > 0, nil
+}
+
+// RI returns the status of the Ring Indicator (RI) modem status line.
+func (p *Port) RI() (bool, error) {
+	status, err := modemStatus(p.h)
+	if err != nil {
+		return false, err
+	}
+	return status&msRingOn > 0, nil
+}
+
+// DCD returns the status of the Data Carrier Detect (DCD) modem status line.
+func (p *Port) DCD() (bool, error) {
+	status, err := modemStatus(p.h)
+	if err != nil {
+		return false, err
+	}
+	return status&msRLSDOn > 0, nil
+}
+
+// ModemStatus reads all six modem control lines. DTR and RTS report the
+// state last requested via SetDTR/SetRTS, since Windows offers no API to
+// read them back from the hardware; CTS, DSR, RI, and DCD come from
+// GetCommModemStatus.
+func (p *Port) ModemStatus() (ModemStatus, error) {
+	status, err := modemStatus(p.h)
+	if err != nil {
+		return ModemStatus{}, err
+	}
+	return ModemStatus{
+		DTR: p.dtr,
+		RTS: p.rts,
+		CTS: status&msCTSOn > 0,
+		DSR: status&msDSROn > 0,
+		RI:  status&msRingOn > 0,
+		DCD: status&msRLSDOn > 0,
+	}, nil
+}
+
+// NewPort creates and returns a new Port struct using the given comm handle
+func NewPort(h syscall.Handle, options OpenOptions) *Port {
+	return &Port{h: h, DeviceName: options.PortName}
+}
@@ -0,0 +1,94 @@
+//go:build linux || darwin
+
+package serial
+
+import (
+	"errors"
+	"os"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// ErrPortClosed is returned by a blocked Read when the Port is concurrently
+// Closed.
+var ErrPortClosed = errors.New("serial: port closed")
+
+// atomicDuration is a time.Duration that can be read and written
+// concurrently, used for the Read timeout, which SetReadTimeout may update
+// while a Read is in flight.
+type atomicDuration struct {
+	nanos atomic.Int64
+}
+
+func (d *atomicDuration) Store(timeout time.Duration) {
+	d.nanos.Store(int64(timeout))
+}
+
+func (d *atomicDuration) Load() time.Duration {
+	return time.Duration(d.nanos.Load())
+}
+
+// newCloseSignal creates a pipe used to unblock a Read parked in
+// waitReadable when the owning Port is Closed.
+func newCloseSignal() (r, w int, err error) {
+	var fds [2]int
+	if err := unix.Pipe(fds[:]); err != nil {
+		return 0, 0, err
+	}
+	return fds[0], fds[1], nil
+}
+
+// fdSet marks fd as a member of set. FdSet.Bits is a word array whose word
+// size differs between Linux (64 bits) and Darwin (32 bits); operating on it
+// byte-by-byte sidesteps that.
+func fdSet(set *unix.FdSet, fd int) {
+	bytes := (*[unsafe.Sizeof(unix.FdSet{}.Bits)]byte)(unsafe.Pointer(&set.Bits))
+	bytes[fd/8] |= 1 << uint(fd%8)
+}
+
+// fdIsSet reports whether fd is a member of set.
+func fdIsSet(set *unix.FdSet, fd int) bool {
+	bytes := (*[unsafe.Sizeof(unix.FdSet{}.Bits)]byte)(unsafe.Pointer(&set.Bits))
+	return bytes[fd/8]&(1<<uint(fd%8)) != 0
+}
+
+// waitReadable blocks until fd has data available to read, the given
+// timeout elapses (returning os.ErrDeadlineExceeded), or closeFD becomes
+// readable (returning ErrPortClosed, signalling a concurrent Close). A
+// timeout of zero blocks indefinitely.
+func waitReadable(fd uintptr, closeFD int, timeout time.Duration) error {
+	for {
+		var tv *unix.Timeval
+		if timeout > 0 {
+			s := unix.NsecToTimeval(timeout.Nanoseconds())
+			tv = &s
+		}
+
+		rfds := &unix.FdSet{}
+		fdSet(rfds, int(fd))
+		fdSet(rfds, closeFD)
+
+		nfd := int(fd)
+		if closeFD > nfd {
+			nfd = closeFD
+		}
+
+		n, err := unix.Select(nfd+1, rfds, nil, nil, tv)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return os.ErrDeadlineExceeded
+		}
+		if fdIsSet(rfds, closeFD) {
+			return ErrPortClosed
+		}
+		return nil
+	}
+}
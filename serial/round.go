@@ -0,0 +1,9 @@
+package serial
+
+// round returns the nearest integer to f, rounding half away from zero.
+func round(f float64) int {
+	if f < 0 {
+		return int(f - 0.5)
+	}
+	return int(f + 0.5)
+}
@@ -0,0 +1,76 @@
+package serial
+
+import (
+	"unsafe"
+)
+
+const (
+	// tiocsrs485 sets the RS-485 configuration of a serial port.
+	tiocsrs485 = 0x542F
+	// tiocgrs485 reads back the RS-485 configuration of a serial port.
+	tiocgrs485 = 0x542E
+)
+
+const (
+	serRS485Enabled      = 1 << 0
+	serRS485RTSOnSend    = 1 << 1
+	serRS485RTSAfterSend = 1 << 2
+	serRS485RxDuringTx   = 1 << 4
+)
+
+// serialRS485 mirrors the kernel's struct serial_rs485.
+type serialRS485 struct {
+	flags              uint32
+	delayRTSBeforeSend uint32
+	delayRTSAfterSend  uint32
+	padding            [5]uint32
+}
+
+func (c RS485Config) toSerialRS485() serialRS485 {
+	var flags uint32
+	if c.Enabled {
+		flags |= serRS485Enabled
+	}
+	if c.RTSOnSend {
+		flags |= serRS485RTSOnSend
+	}
+	if c.RTSAfterSend {
+		flags |= serRS485RTSAfterSend
+	}
+	if c.RxDuringTx {
+		flags |= serRS485RxDuringTx
+	}
+
+	return serialRS485{
+		flags:              flags,
+		delayRTSBeforeSend: c.DelayRTSBeforeSend,
+		delayRTSAfterSend:  c.DelayRTSAfterSend,
+	}
+}
+
+func rs485ConfigFrom(s serialRS485) RS485Config {
+	return RS485Config{
+		Enabled:            s.flags&serRS485Enabled != 0,
+		RTSOnSend:          s.flags&serRS485RTSOnSend != 0,
+		RTSAfterSend:       s.flags&serRS485RTSAfterSend != 0,
+		RxDuringTx:         s.flags&serRS485RxDuringTx != 0,
+		DelayRTSBeforeSend: s.delayRTSBeforeSend,
+		DelayRTSAfterSend:  s.delayRTSAfterSend,
+	}
+}
+
+// SetRS485 puts the port into (or out of) RS-485 half-duplex mode, with the
+// kernel toggling RTS around transmission as described by cfg.
+func (p *Port) SetRS485(cfg RS485Config) error {
+	s := cfg.toSerialRS485()
+	return ioctl(tiocsrs485, p.fd, uintptr(unsafe.Pointer(&s)))
+}
+
+// GetRS485 returns the port's current RS-485 configuration.
+func (p *Port) GetRS485() (RS485Config, error) {
+	var s serialRS485
+	if err := ioctl(tiocgrs485, p.fd, uintptr(unsafe.Pointer(&s))); err != nil {
+		return RS485Config{}, err
+	}
+	return rs485ConfigFrom(s), nil
+}
@@ -0,0 +1,154 @@
+// Package serial provides a cross-platform interface for discovering,
+// opening, and talking to serial ports.
+package serial
+
+// Parity describes the parity bit scheme used to frame each byte on the
+// wire.
+type Parity int
+
+const (
+	// ParityNone disables parity checking.
+	ParityNone Parity = iota
+	// ParityOdd sets the parity bit so the number of 1 bits, including the
+	// parity bit, is odd.
+	ParityOdd
+	// ParityEven sets the parity bit so the number of 1 bits, including the
+	// parity bit, is even.
+	ParityEven
+	// ParityMark always sets the parity bit to 1. On Darwin, which has no
+	// CMSPAR, it selects odd parity instead.
+	ParityMark
+	// ParitySpace always sets the parity bit to 0. On Darwin, which has no
+	// CMSPAR, it selects even parity instead.
+	ParitySpace
+)
+
+// StopBits describes the number of stop bits used to frame each byte on the
+// wire.
+type StopBits int
+
+const (
+	// Stop1 uses one stop bit.
+	Stop1 StopBits = iota
+	// Stop1Half uses one and a half stop bits on Windows. On Linux and
+	// Darwin it selects the same termios setting (CSTOPB) as Stop2.
+	Stop1Half
+	// Stop2 uses two stop bits.
+	Stop2
+)
+
+// RS485Config describes RS-485 half-duplex transceiver control, as applied
+// by Port.SetRS485. It is only supported on Linux.
+type RS485Config struct {
+	// Enabled turns RS-485 support on for the port.
+	Enabled bool
+
+	// RTSOnSend is the state the RTS line is driven to while sending.
+	RTSOnSend bool
+
+	// RTSAfterSend is the state the RTS line is driven to after a send
+	// completes.
+	RTSAfterSend bool
+
+	// RxDuringTx allows the receiver to stay enabled while sending.
+	RxDuringTx bool
+
+	// DelayRTSBeforeSend is how long, in microseconds, to hold RTS before
+	// data is sent.
+	DelayRTSBeforeSend uint32
+
+	// DelayRTSAfterSend is how long, in microseconds, to hold RTS after
+	// data has been sent.
+	DelayRTSAfterSend uint32
+}
+
+// OpenOptions describes how to open and configure a serial port.
+type OpenOptions struct {
+	// PortName is the name of the port to open, e.g. "/dev/ttyUSB0" on
+	// Linux, "/dev/cu.usbserial" on Darwin, or "COM3" on Windows.
+	PortName string
+
+	// BaudRate is the speed at which the port should be opened, e.g. 9600 or
+	// 115200. Non-standard baud rates are supported on platforms that allow
+	// it.
+	BaudRate uint
+
+	// DataBits is the number of data bits per frame, one of 5, 6, 7, or 8.
+	// Zero is treated as 8.
+	DataBits uint
+
+	// Parity is the parity scheme to use when framing each byte. The zero
+	// value is ParityNone.
+	Parity Parity
+
+	// StopBits is the number of stop bits to use when framing each byte.
+	// The zero value is Stop1.
+	StopBits StopBits
+
+	// RTSCTSFlowControl enables RTS/CTS hardware flow control.
+	RTSCTSFlowControl bool
+
+	// XONXOFFFlowControl enables XON/XOFF software flow control.
+	XONXOFFFlowControl bool
+
+	// MinimumReadSize is the minimum number of bytes that must be read
+	// before a Read call returns, as passed to the termios VMIN setting.
+	MinimumReadSize uint
+
+	// InterCharacterTimeout is the maximum time, in milliseconds, to wait
+	// between characters before a Read call returns, as passed to the
+	// termios VTIME setting.
+	InterCharacterTimeout uint
+
+	// RS485 configures RS-485 half-duplex mode and auto-RTS toggling. It is
+	// only honored on Linux; see Port.SetRS485.
+	RS485 RS485Config
+}
+
+// Open opens a serial port using the given options, returning a ready to use
+// Port or an error describing why the port could not be opened.
+func Open(options OpenOptions) (*Port, error) {
+	return openInternal(options)
+}
+
+// PortInfo describes a serial port discovered by List, along with whatever
+// USB descriptor metadata could be recovered for it. Fields that could not
+// be determined are left as the empty string.
+type PortInfo struct {
+	// Name is the platform-specific path or name to pass as
+	// OpenOptions.PortName, e.g. "/dev/ttyUSB0" or "COM3".
+	Name string
+
+	// Description is a human-readable description of the device, if any was
+	// advertised by the driver or bus.
+	Description string
+
+	// VID is the USB vendor ID, formatted as a four hex digit string, e.g.
+	// "0403".
+	VID string
+
+	// PID is the USB product ID, formatted as a four hex digit string.
+	PID string
+
+	// SerialNumber is the device's USB serial number string, if any.
+	SerialNumber string
+
+	// Manufacturer is the device's USB manufacturer string, if any.
+	Manufacturer string
+}
+
+// List returns the serial ports currently present on the system.
+func List() ([]PortInfo, error) {
+	return listPorts()
+}
+
+// ModemStatus reports the state of a port's modem control lines, as
+// returned by Port.ModemStatus.
+type ModemStatus struct {
+	DTR bool
+	RTS bool
+	CTS bool
+	DSR bool
+	RI  bool
+	DCD bool
+}